@@ -0,0 +1,95 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/daveshanley/vacuum/cui"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// GetRulesetCommand returns the parent `vacuum ruleset` command, grouping ruleset
+// related tooling (compile, and friends) under a single noun.
+func GetRulesetCommand() *cobra.Command {
+
+	rulesetCmd := &cobra.Command{
+		Use:   "ruleset",
+		Short: "Work with vacuum rulesets",
+	}
+
+	rulesetCmd.AddCommand(getRulesetCompileCommand())
+	rulesetCmd.AddCommand(getRulesetFetchCommand())
+
+	return rulesetCmd
+}
+
+func getRulesetCompileCommand() *cobra.Command {
+
+	return &cobra.Command{
+		Use:     "compile",
+		Short:   "Compile a YAML ruleset into a pre-compiled binary .vrs file",
+		Long:    "Parse and validate a YAML ruleset once, then write it out as a binary .vrs file that can be loaded without re-parsing or re-validating it.",
+		Example: "vacuum ruleset compile my-ruleset.yaml my-ruleset.vrs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if len(args) == 0 {
+				errText := "please supply a YAML ruleset to compile"
+				pterm.Error.Println(errText)
+				return errors.New(errText)
+			}
+
+			rsBytes, rsErr := ioutil.ReadFile(args[0])
+			if rsErr != nil {
+				pterm.Error.Printf("Unable to read ruleset file '%s': %s\n", args[0], rsErr.Error())
+				return rsErr
+			}
+
+			if rulesets.IsCompiledRuleSet(rsBytes) {
+				errText := fmt.Sprintf("'%s' is already a compiled .vrs ruleset", args[0])
+				pterm.Error.Println(errText)
+				return errors.New(errText)
+			}
+
+			defaultRuleSets := rulesets.BuildDefaultRuleSets()
+			selectedRS, rsErr := cui.BuildRuleSetFromUserSuppliedSet(rsBytes, defaultRuleSets)
+			if rsErr != nil {
+				return rsErr
+			}
+
+			if rsErr = rulesets.ValidateRuleSet(selectedRS); rsErr != nil {
+				pterm.Error.Printf("Invalid ruleset '%s': %s\n", args[0], rsErr.Error())
+				return rsErr
+			}
+
+			compiled, compileErr := rulesets.CompileRuleSet(selectedRS)
+			if compileErr != nil {
+				pterm.Error.Printf("Unable to compile ruleset '%s': %s\n", args[0], compileErr.Error())
+				return compileErr
+			}
+
+			output := args[0]
+			if len(args) > 1 {
+				output = args[1]
+			} else {
+				output = strings.TrimSuffix(output, filepath.Ext(output)) + ".vrs"
+			}
+
+			if err := ioutil.WriteFile(output, compiled, 0664); err != nil {
+				pterm.Error.Printf("Unable to write compiled ruleset: '%s': %s\n", output, err.Error())
+				return err
+			}
+
+			pterm.Info.Printf("Ruleset '%s' compiled and written to '%s'\n", args[0], output)
+			pterm.Println()
+
+			return nil
+		},
+	}
+}
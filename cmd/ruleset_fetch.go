@@ -0,0 +1,62 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getRulesetFetchCommand() *cobra.Command {
+
+	return &cobra.Command{
+		Use:     "fetch <owner>/<repo>[@tag]",
+		Short:   "Download and cache a community ruleset from a GitHub release",
+		Example: "vacuum ruleset fetch daveshanley/vacuum-rules@v1.0.0",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if len(args) == 0 {
+				errText := "please supply a ruleset to fetch, e.g. owner/repo@tag"
+				pterm.Error.Println(errText)
+				return errors.New(errText)
+			}
+
+			ref := args[0]
+			if !rulesets.IsGitHubRef(ref) {
+				ref = "github:" + ref
+			}
+
+			owner, repo, tag, err := rulesets.ParseGitHubRef(ref)
+			if err != nil {
+				pterm.Error.Println(err.Error())
+				return err
+			}
+
+			fetcher, err := rulesets.NewFetcher()
+			if err != nil {
+				return err
+			}
+
+			dir, fetchErr := fetcher.Fetch(owner, repo, tag)
+			if fetchErr == rulesets.ErrTagAlreadyCached {
+				pterm.Info.Printf("'%s/%s@%s' is already cached at '%s'\n", owner, repo, tag, dir)
+				return nil
+			}
+			if fetchErr != nil {
+				var rateLimitErr *rulesets.ErrRateLimited
+				if errors.As(fetchErr, &rateLimitErr) {
+					pterm.Error.Println(rateLimitErr.Error())
+				} else {
+					pterm.Error.Printf("Unable to fetch '%s/%s@%s': %s\n", owner, repo, tag, fetchErr.Error())
+				}
+				return fetchErr
+			}
+
+			pterm.Info.Printf("'%s/%s@%s' cached at '%s'\n", owner, repo, tag, dir)
+			return nil
+		},
+	}
+}
@@ -19,7 +19,7 @@ import (
 
 func GetSpectralReportCommand() *cobra.Command {
 
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "report",
 		Short: "Generate a Spectral compatible JSON report",
 		Long: "Generate a JSON report using the same model as Spectral. Default output " +
@@ -55,6 +55,9 @@ func GetSpectralReportCommand() *cobra.Command {
 			}
 
 			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			environmentFlag, _ := cmd.Flags().GetString("environment")
+			valuesFlag, _ := cmd.Flags().GetString("values")
+			allowOverridesFlag, _ := cmd.Flags().GetBool("allow-overrides")
 
 			// read spec and parse to dashboard.
 			defaultRuleSets := rulesets.BuildDefaultRuleSets()
@@ -66,15 +69,82 @@ func GetSpectralReportCommand() *cobra.Command {
 			// and see if it's valid. If so - let's go!
 			if rulesetFlag != "" {
 
-				rsBytes, rsErr := ioutil.ReadFile(rulesetFlag)
-				if rsErr != nil {
-					pterm.Error.Printf("Unable to read ruleset file '%s': %s\n", rulesetFlag, rsErr.Error())
-					pterm.Println()
-					return rsErr
+				// a "github:owner/repo@tag" reference resolves through the local
+				// ruleset cache (fetching it first if this is the first use of that tag).
+				if rulesets.IsGitHubRef(rulesetFlag) {
+					resolved, resolveErr := rulesets.ResolveGitHubRef(rulesetFlag)
+					if resolveErr != nil {
+						pterm.Error.Printf("Unable to resolve ruleset '%s': %s\n", rulesetFlag, resolveErr.Error())
+						pterm.Println()
+						return resolveErr
+					}
+					rulesetFlag = resolved
 				}
-				selectedRS, rsErr = cui.BuildRuleSetFromUserSuppliedSet(rsBytes, defaultRuleSets)
-				if rsErr != nil {
-					return rsErr
+
+				// a directory of rulesets is auto-discovered and merged into one
+				// effective ruleset, so a house style can be composed from many
+				// small shareable rule packs instead of one monolithic YAML.
+				if fi, statErr := os.Stat(rulesetFlag); statErr == nil && fi.IsDir() {
+
+					buildFragment := func(raw []byte) (*rulesets.RuleSet, error) {
+						rs, buildErr := cui.BuildRuleSetFromUserSuppliedSet(raw, defaultRuleSets)
+						if buildErr != nil {
+							return nil, buildErr
+						}
+						if buildErr = rulesets.ValidateRuleSet(rs); buildErr != nil {
+							return nil, buildErr
+						}
+						return rs, nil
+					}
+
+					mergedRS, summary, mergeErr := rulesets.LoadDirectory(rulesetFlag, allowOverridesFlag, buildFragment)
+					if mergeErr != nil {
+						pterm.Error.Printf("Unable to merge rulesets in '%s': %s\n", rulesetFlag, mergeErr.Error())
+						pterm.Println()
+						return mergeErr
+					}
+					for _, path := range summary.Paths {
+						pterm.Info.Printf("Loaded rules from '%s'\n", path)
+					}
+					selectedRS = mergedRS
+
+				} else {
+
+					rsBytes, rsErr := ioutil.ReadFile(rulesetFlag)
+					if rsErr != nil {
+						pterm.Error.Printf("Unable to read ruleset file '%s': %s\n", rulesetFlag, rsErr.Error())
+						pterm.Println()
+						return rsErr
+					}
+
+					// a pre-compiled .vrs ruleset skips parsing and validation entirely,
+					// which is the fast path for CI runs with large rulesets.
+					if rulesets.IsCompiledRuleSet(rsBytes) {
+						selectedRS, rsErr = rulesets.LoadCompiledRuleSet(rsBytes)
+						if rsErr != nil {
+							pterm.Error.Printf("Unable to load compiled ruleset '%s': %s\n", rulesetFlag, rsErr.Error())
+							pterm.Println()
+							return rsErr
+						}
+					} else {
+						if environmentFlag != "" || valuesFlag != "" {
+							rsBytes, rsErr = rulesets.RenderTemplatedRuleSet(rsBytes, environmentFlag, valuesFlag)
+							if rsErr != nil {
+								pterm.Error.Printf("Unable to render ruleset template '%s': %s\n", rulesetFlag, rsErr.Error())
+								pterm.Println()
+								return rsErr
+							}
+						}
+						selectedRS, rsErr = cui.BuildRuleSetFromUserSuppliedSet(rsBytes, defaultRuleSets)
+						if rsErr != nil {
+							return rsErr
+						}
+						if rsErr = rulesets.ValidateRuleSet(selectedRS); rsErr != nil {
+							pterm.Error.Printf("Invalid ruleset '%s': %s\n", rulesetFlag, rsErr.Error())
+							pterm.Println()
+							return rsErr
+						}
+					}
 				}
 			}
 
@@ -121,4 +191,9 @@ func GetSpectralReportCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String("environment", "", "environment block from the ruleset's 'environments:' section to resolve {{ .Values }} against")
+	cmd.Flags().String("values", "", "YAML file of values to resolve {{ .Values }} against, merged on top of --environment")
+	cmd.Flags().Bool("allow-overrides", false, "when --ruleset is a directory, allow later files to override an earlier file's rule with a conflicting severity")
+
+	return cmd
 }
\ No newline at end of file
@@ -0,0 +1,146 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/daveshanley/vacuum/cui"
+	"github.com/daveshanley/vacuum/grpcapi"
+	"github.com/daveshanley/vacuum/grpcapi/lintv1"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/watch"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"io/ioutil"
+)
+
+// GetWatchCommand returns the `vacuum watch` command: a long-running mode that
+// re-lints spec files as they change and prints a rolling report, optionally
+// also serving the same results over gRPC for editor plugins and dashboards.
+func GetWatchCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:     "watch",
+		Short:   "Watch spec files and re-lint them on every change",
+		Example: "vacuum watch my-spec.yaml another-spec.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if len(args) == 0 {
+				errText := "please supply at least one OpenAPI specification to watch"
+				pterm.Error.Println(errText)
+				return errors.New(errText)
+			}
+
+			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			grpcAddr, _ := cmd.Flags().GetString("grpc-addr")
+
+			defaultRuleSets := rulesets.BuildDefaultRuleSets()
+			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+
+			if rulesetFlag != "" {
+				rsBytes, rsErr := ioutil.ReadFile(rulesetFlag)
+				if rsErr != nil {
+					pterm.Error.Printf("Unable to read ruleset file '%s': %s\n", rulesetFlag, rsErr.Error())
+					return rsErr
+				}
+				selectedRS, rsErr = cui.BuildRuleSetFromUserSuppliedSet(rsBytes, defaultRuleSets)
+				if rsErr != nil {
+					return rsErr
+				}
+			}
+
+			hub := watch.NewHub()
+
+			watcher, err := watch.NewWatcher(selectedRS, hub)
+			if err != nil {
+				return err
+			}
+			defer watcher.Close()
+
+			if err := watcher.Watch(args...); err != nil {
+				return err
+			}
+
+			if grpcAddr != "" {
+				if err := serveLintGRPC(grpcAddr, hub); err != nil {
+					return err
+				}
+				pterm.Info.Printf("Serving LintService on %s\n", grpcAddr)
+			}
+
+			return printRollingReport(hub, args)
+		},
+	}
+
+	cmd.Flags().String("grpc-addr", "", "address to serve the LintService gRPC API on, e.g. :50051")
+
+	return cmd
+}
+
+// serveLintGRPC starts the LintService in the background, bound to addr.
+func serveLintGRPC(addr string, hub *watch.Hub) error {
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to bind LintService to '%s': %w", addr, err)
+	}
+
+	server := grpc.NewServer()
+	lintv1.RegisterLintServiceServer(server, grpcapi.NewLintServer(hub))
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	return nil
+}
+
+// printRollingReport subscribes to every watched path and prints each delta to
+// the terminal as it arrives, blocking until the process is interrupted.
+func printRollingReport(hub *watch.Hub, paths []string) error {
+
+	type subscription struct {
+		path    string
+		deltas  <-chan watch.Delta
+		cleanup func()
+	}
+
+	subs := make([]subscription, 0, len(paths))
+	for _, path := range paths {
+		deltas, unsubscribe := hub.Subscribe(path, watch.Filter{})
+		subs = append(subs, subscription{path: path, deltas: deltas, cleanup: unsubscribe})
+		defer unsubscribe()
+	}
+
+	pterm.Info.Println("Watching for changes, press ctrl+c to stop")
+
+	cases := make(chan watch.Delta)
+	for _, sub := range subs {
+		go func(s subscription) {
+			for delta := range s.deltas {
+				cases <- delta
+			}
+		}(sub)
+	}
+
+	for delta := range cases {
+		pterm.Println()
+		pterm.DefaultSection.Printf("%s", delta.Path)
+		for _, r := range delta.Added {
+			pterm.Warning.Printf("+ %s\n", r.Message)
+		}
+		for _, r := range delta.Removed {
+			pterm.Success.Printf("- %s\n", r.Message)
+		}
+		for _, r := range delta.Changed {
+			pterm.Info.Printf("~ %s\n", r.Message)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"fmt"
+	"github.com/daveshanley/vaccum/model"
+	"github.com/daveshanley/vaccum/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefinedAll checks that every field named in the 'fields' option is present.
+type DefinedAll struct {
+}
+
+func (d DefinedAll) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	fields := fieldsFromOptions(context.RuleAction.FunctionOptions)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	for _, node := range nodes {
+		for _, field := range fields {
+			fieldNode, _ := utils.FindKeyNode(field, node.Content)
+			if fieldNode == nil {
+				results = append(results, model.RuleFunctionResult{
+					Message:   fmt.Sprintf("'%s' must be defined", field),
+					StartNode: node,
+					Path:      context.Given,
+				})
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vaccum/model"
+	"github.com/daveshanley/vaccum/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefinedAny checks that at least one of the fields named in the 'fields'
+// option is present.
+type DefinedAny struct {
+}
+
+func (d DefinedAny) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	fields := fieldsFromOptions(context.RuleAction.FunctionOptions)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	for _, node := range nodes {
+
+		found := false
+		for _, field := range fields {
+			if fieldNode, _ := utils.FindKeyNode(field, node.Content); fieldNode != nil {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			results = append(results, model.RuleFunctionResult{
+				Message:   fmt.Sprintf("at least one of '%s' must be defined", strings.Join(fields, "', '")),
+				StartNode: node,
+				Path:      context.Given,
+			})
+		}
+	}
+
+	return results
+}
+
+// fieldsFromOptions reads the 'fields' option as a list of field names.
+func fieldsFromOptions(options map[string]interface{}) []string {
+
+	if options == nil {
+		return nil
+	}
+
+	raw, ok := options["fields"]
+	if !ok {
+		return nil
+	}
+
+	switch v := raw.(type) {
+	case []interface{}:
+		fields := make([]string, 0, len(v))
+		for _, f := range v {
+			if s, ok := f.(string); ok {
+				fields = append(fields, s)
+			}
+		}
+		return fields
+	case string:
+		return []string{v}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"fmt"
+	"github.com/daveshanley/vaccum/model"
+	"github.com/daveshanley/vaccum/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefinedIf checks that 'field' is present only when a sibling field ('field'
+// under the 'if' option) equals 'equals'.
+type DefinedIf struct {
+}
+
+func (d DefinedIf) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	ifField, equals := ifOptions(context.RuleAction.FunctionOptions)
+	if ifField == "" {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	for _, node := range nodes {
+
+		ifFieldNode, ifValueNode := utils.FindKeyNode(ifField, node.Content)
+		if ifFieldNode == nil || ifValueNode == nil || ifValueNode.Value != equals {
+			continue
+		}
+
+		fieldNode, _ := utils.FindKeyNode(context.RuleAction.Field, node.Content)
+		if fieldNode == nil {
+			results = append(results, model.RuleFunctionResult{
+				Message:   fmt.Sprintf("'%s' must be defined when '%s' is '%s'", context.RuleAction.Field, ifField, equals),
+				StartNode: node,
+				Path:      context.Given,
+			})
+		}
+	}
+
+	return results
+}
+
+// ifOptions reads 'field' and 'equals', stringifying 'equals' so non-string
+// YAML scalars (e.g. 'equals: true') still compare correctly against a
+// yaml.Node's Value, which is always a string.
+func ifOptions(options map[string]interface{}) (field, equals string) {
+
+	if options == nil {
+		return "", ""
+	}
+
+	if f, ok := options["field"].(string); ok {
+		field = f
+	}
+	if e, ok := options["equals"]; ok {
+		equals = fmt.Sprintf("%v", e)
+	}
+	return field, equals
+}
@@ -0,0 +1,74 @@
+// Package grpcapi implements the LintService defined in api/lint/v1/lint.proto.
+// Run `make proto` to (re)generate the grpcapi/lintv1 package this file
+// depends on; it is a build artifact and isn't checked in.
+package grpcapi
+
+import (
+	"github.com/daveshanley/vacuum/grpcapi/lintv1"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/watch"
+)
+
+// LintServer implements lintv1.LintServiceServer on top of a watch.Hub, so every
+// subscriber shares the one lint pipeline the CLI's `vacuum watch` is already
+// running rather than spawning its own.
+type LintServer struct {
+	lintv1.UnimplementedLintServiceServer
+
+	hub *watch.Hub
+}
+
+// NewLintServer wires a LintServer to an existing Hub.
+func NewLintServer(hub *watch.Hub) *LintServer {
+	return &LintServer{hub: hub}
+}
+
+// FollowResults streams deltas for req.Path until the client disconnects.
+func (s *LintServer) FollowResults(req *lintv1.SpecRef, stream lintv1.LintService_FollowResultsServer) error {
+
+	deltas, unsubscribe := s.hub.Subscribe(req.Path, watch.Filter{
+		MinSeverity: req.MinSeverity,
+		Categories:  req.Categories,
+	})
+	defer unsubscribe()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case delta, ok := <-deltas:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toLintEvent(delta)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toLintEvent(d watch.Delta) *lintv1.LintEvent {
+	return &lintv1.LintEvent{
+		Path:    d.Path,
+		Added:   toRuleResults(d.Added),
+		Removed: toRuleResults(d.Removed),
+		Changed: toRuleResults(d.Changed),
+	}
+}
+
+func toRuleResults(results []model.RuleFunctionResult) []*lintv1.RuleResult {
+	out := make([]*lintv1.RuleResult, 0, len(results))
+	for _, r := range results {
+		out = append(out, &lintv1.RuleResult{
+			RuleId:      r.Rule.Id,
+			Message:     r.Message,
+			Severity:    r.Rule.Severity,
+			Path:        r.Path,
+			StartLine:   int32(r.StartNode.Line),
+			StartColumn: int32(r.StartNode.Column),
+		})
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package rulesets
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+)
+
+func init() {
+	// Rule.Given and RuleAction.FunctionOptions are interface{}-typed so a rule
+	// can hold a single JSONPath string, a list of them, or a nested options
+	// map; gob needs the concrete types registered up front or it fails to
+	// encode/decode them.
+	gob.Register([]interface{}{})
+	gob.Register(map[string]interface{}{})
+}
+
+// vrsMagic is written as the first four bytes of every compiled ruleset file so that
+// callers can tell a pre-compiled binary ruleset apart from a plain YAML one without
+// needing to parse it first.
+var vrsMagic = [4]byte{'V', 'R', 'S', 0x1}
+
+// vrsVersion is the current binary schema version. It's bumped whenever the shape of
+// compiledRuleSet changes in a way that isn't backwards compatible, so LoadCompiled can
+// detect stale files and ask the caller to recompile rather than decoding garbage.
+const vrsVersion uint8 = 1
+
+// compiledRuleSet is the gob-encoded payload stored in a .vrs file, wrapped with enough
+// metadata to support future upgrades.
+type compiledRuleSet struct {
+	Version uint8
+	RuleSet *RuleSet
+}
+
+// IsCompiledRuleSet reports whether data begins with the .vrs magic bytes. Callers use
+// this to decide whether a --ruleset file should be decoded directly or parsed as YAML.
+func IsCompiledRuleSet(data []byte) bool {
+	if len(data) < len(vrsMagic) {
+		return false
+	}
+	return bytes.Equal(data[:len(vrsMagic)], vrsMagic[:])
+}
+
+// CompileRuleSet serializes an already parsed and validated RuleSet into the binary
+// .vrs format, so it can be loaded again later without re-parsing or re-validating the
+// source YAML. Only the parsed YAML shape is persisted; anything a function compiles
+// from it at run time (e.g. a pattern function's regexp from a FunctionOptions string)
+// isn't part of the Rule itself and is recompiled on first use as usual.
+func CompileRuleSet(rs *RuleSet) ([]byte, error) {
+
+	var buf bytes.Buffer
+	buf.Write(vrsMagic[:])
+
+	if err := gob.NewEncoder(&buf).Encode(&compiledRuleSet{
+		Version: vrsVersion,
+		RuleSet: rs,
+	}); err != nil {
+		return nil, fmt.Errorf("unable to encode compiled ruleset: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// LoadCompiledRuleSet decodes a .vrs payload produced by CompileRuleSet. It skips all
+// YAML parsing and rule validation, which is the whole point of the fast path: a large
+// ruleset that would otherwise dominate CLI startup time loads back in one gob decode.
+func LoadCompiledRuleSet(data []byte) (*RuleSet, error) {
+
+	if !IsCompiledRuleSet(data) {
+		return nil, fmt.Errorf("data does not begin with the vacuum ruleset magic bytes")
+	}
+
+	var crs compiledRuleSet
+	if err := gob.NewDecoder(bytes.NewReader(data[len(vrsMagic):])).Decode(&crs); err != nil {
+		return nil, fmt.Errorf("unable to decode compiled ruleset: %w", err)
+	}
+
+	if crs.Version != vrsVersion {
+		// nothing to upgrade from yet, but this is where a future migration step
+		// (e.g. upgradeV1ToV2) would hook in before returning the rule set.
+		return nil, fmt.Errorf("compiled ruleset is version %d, vacuum supports version %d: "+
+			"recompile it with 'vacuum ruleset compile'", crs.Version, vrsVersion)
+	}
+
+	return crs.RuleSet, nil
+}
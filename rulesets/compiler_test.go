@@ -0,0 +1,46 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileRuleSet_RoundTrip(t *testing.T) {
+
+	rs := &RuleSet{
+		DocumentationURI: "https://quobix.com/vacuum/rules",
+		Description:      "a test ruleset",
+		Rules: map[string]*Rule{
+			"test-rule": {
+				Description: "checks something",
+				Severity:    "error",
+				Given:       []interface{}{"$.paths[*]", "$.components.schemas[*]"},
+				Then: RuleAction{
+					Field:    "title",
+					Function: "definedAll",
+					FunctionOptions: map[string]interface{}{
+						"fields": []interface{}{"title", "version"},
+					},
+				},
+			},
+		},
+	}
+
+	compiled, err := CompileRuleSet(rs)
+	assert.NoError(t, err)
+	assert.True(t, IsCompiledRuleSet(compiled))
+
+	loaded, err := LoadCompiledRuleSet(compiled)
+	assert.NoError(t, err)
+	assert.Equal(t, rs, loaded)
+}
+
+func TestIsCompiledRuleSet_PlainYAML(t *testing.T) {
+	assert.False(t, IsCompiledRuleSet([]byte("rules:\n  test-rule:\n    severity: error\n")))
+}
+
+func TestLoadCompiledRuleSet_WrongMagic(t *testing.T) {
+	_, err := LoadCompiledRuleSet([]byte("not a vrs file"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package rulesets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ruleSetFragment is one source of rules discovered while walking a ruleset
+// directory: either a self-contained ruleset.yaml or a loose ad-hoc rule file.
+type ruleSetFragment struct {
+	path    string
+	ruleSet *RuleSet
+}
+
+// DirectorySummary records which file contributed which rule ids.
+type DirectorySummary struct {
+	// Contributions maps a rule id to the path of the file it was loaded from.
+	Contributions map[string]string
+	// Paths lists every fragment file that was merged in, in the deterministic
+	// order they were applied.
+	Paths []string
+}
+
+// RuleSetBuilder parses raw ruleset YAML into a RuleSet. LoadDirectory takes
+// one in rather than importing cui itself (cui already imports rulesets to
+// build its default sets, so rulesets importing cui back would cycle); the
+// caller passes in cui.BuildRuleSetFromUserSuppliedSet so every fragment goes
+// through the same normalization and validation a single-file --ruleset does.
+type RuleSetBuilder func(raw []byte) (*RuleSet, error)
+
+// LoadDirectory walks dir, treating any subdirectory that contains a
+// ruleset.yaml at any depth as a self-contained ruleset and any loose
+// *.yaml/*.yml file at the top level as an ad-hoc rule fragment, then merges
+// all of them into one effective RuleSet. Fragments are applied in
+// deterministic path order, later fragments overriding earlier ones by rule
+// id. If two fragments define the same rule id with different severities,
+// LoadDirectory fails unless allowOverrides is set.
+func LoadDirectory(dir string, allowOverrides bool, build RuleSetBuilder) (*RuleSet, *DirectorySummary, error) {
+
+	fragments, err := discoverFragments(dir, build)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(fragments, func(i, j int) bool { return fragments[i].path < fragments[j].path })
+
+	merged := &RuleSet{Rules: make(map[string]*Rule)}
+	summary := &DirectorySummary{Contributions: make(map[string]string)}
+
+	for _, fragment := range fragments {
+		summary.Paths = append(summary.Paths, fragment.path)
+
+		for id, rule := range fragment.ruleSet.Rules {
+			if existing, ok := merged.Rules[id]; ok && !allowOverrides && conflicts(existing, rule) {
+				return nil, nil, fmt.Errorf(
+					"rule '%s' is defined with conflicting severities in '%s' and '%s': pass --allow-overrides to allow this",
+					id, summary.Contributions[id], fragment.path)
+			}
+			merged.Rules[id] = rule
+			summary.Contributions[id] = fragment.path
+		}
+	}
+
+	return merged, summary, nil
+}
+
+func conflicts(a, b *Rule) bool {
+	return a.Severity != b.Severity
+}
+
+// discoverFragments finds every fragment under dir: loose *.yaml/*.yml files
+// directly inside dir, plus a ruleset.yaml inside any of dir's
+// subdirectories at any depth (house-styles/team-a/api/ruleset.yaml included).
+func discoverFragments(dir string, build RuleSetBuilder) ([]ruleSetFragment, error) {
+
+	var fragments []ruleSetFragment
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ruleset directory '%s': %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if entry.IsDir() {
+			nested, err := discoverRuleSetsUnder(path, build)
+			if err != nil {
+				return nil, err
+			}
+			fragments = append(fragments, nested...)
+			continue
+		}
+
+		if !isYAMLFile(entry.Name()) {
+			continue
+		}
+
+		rs, loadErr := loadFragment(path, build)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		fragments = append(fragments, ruleSetFragment{path: path, ruleSet: rs})
+	}
+
+	return fragments, nil
+}
+
+// discoverRuleSetsUnder walks root looking for a ruleset.yaml at any depth.
+func discoverRuleSetsUnder(root string, build RuleSetBuilder) ([]ruleSetFragment, error) {
+
+	var fragments []ruleSetFragment
+
+	walkErr := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() != "ruleset.yaml" {
+			return nil
+		}
+
+		rs, loadErr := loadFragment(path, build)
+		if loadErr != nil {
+			return loadErr
+		}
+		fragments = append(fragments, ruleSetFragment{path: path, ruleSet: rs})
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("unable to walk ruleset directory '%s': %w", root, walkErr)
+	}
+
+	return fragments, nil
+}
+
+func isYAMLFile(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+func loadFragment(path string, build RuleSetBuilder) (*RuleSet, error) {
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", path, err)
+	}
+
+	rs, err := build(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse '%s': %w", path, err)
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,76 @@
+package rulesets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// testBuilder is a stand-in for cui.BuildRuleSetFromUserSuppliedSet so these
+// tests can exercise LoadDirectory's traversal and merge logic without
+// depending on the cui package.
+func testBuilder(raw []byte) (*RuleSet, error) {
+	var rs RuleSet
+	if err := yaml.Unmarshal(raw, &rs); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+func TestLoadDirectory_MergesByRuleID(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vacuum-ruleset-dir")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "house-style"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "house-style", "ruleset.yaml"),
+		[]byte("rules:\n  require-title:\n    severity: warn\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "zz-overrides.yaml"),
+		[]byte("rules:\n  require-title:\n    severity: warn\n  require-version:\n    severity: error\n"), 0644))
+
+	merged, summary, err := LoadDirectory(dir, false, testBuilder)
+	assert.NoError(t, err)
+	assert.Len(t, merged.Rules, 2)
+	assert.Equal(t, 2, len(summary.Paths))
+}
+
+func TestLoadDirectory_ConflictingSeverityFailsWithoutAllowOverrides(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vacuum-ruleset-dir")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.yaml"),
+		[]byte("rules:\n  require-title:\n    severity: warn\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.yaml"),
+		[]byte("rules:\n  require-title:\n    severity: error\n"), 0644))
+
+	_, _, err = LoadDirectory(dir, false, testBuilder)
+	assert.Error(t, err)
+
+	merged, _, err := LoadDirectory(dir, true, testBuilder)
+	assert.NoError(t, err)
+	assert.Len(t, merged.Rules, 1)
+}
+
+func TestLoadDirectory_FindsNestedRuleSets(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vacuum-ruleset-dir")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	nested := filepath.Join(dir, "house-styles", "team-a", "api")
+	assert.NoError(t, os.MkdirAll(nested, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(nested, "ruleset.yaml"),
+		[]byte("rules:\n  require-title:\n    severity: warn\n"), 0644))
+
+	merged, summary, err := LoadDirectory(dir, false, testBuilder)
+	assert.NoError(t, err)
+	assert.Len(t, merged.Rules, 1)
+	assert.Equal(t, []string{filepath.Join(nested, "ruleset.yaml")}, summary.Paths)
+}
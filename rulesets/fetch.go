@@ -0,0 +1,279 @@
+package rulesets
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const githubAPIBase = "https://api.github.com"
+
+// ErrRateLimited is returned by Fetch when GitHub's rate limit has been
+// exhausted, carrying the time the caller can retry at.
+type ErrRateLimited struct {
+	ResetAt time.Time
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("GitHub API rate limit exceeded, resets at %s", e.ResetAt.In(time.Local).Format(time.RFC1123))
+}
+
+// ErrTagAlreadyCached is returned by Fetch when owner/repo@tag is already
+// present in the cache.
+var ErrTagAlreadyCached = fmt.Errorf("ruleset tag is already cached, refusing to overwrite it")
+
+// Fetcher downloads and caches community ruleset bundles published as GitHub
+// releases.
+type Fetcher struct {
+	httpClient *http.Client
+	cacheDir   string
+}
+
+// NewFetcher builds a Fetcher that caches under $XDG_CACHE_HOME/vacuum/rulesets
+// (or the OS default cache dir if that's unset).
+func NewFetcher() (*Fetcher, error) {
+
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine cache directory: %w", err)
+	}
+
+	return &Fetcher{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		cacheDir:   filepath.Join(base, "vacuum", "rulesets"),
+	}, nil
+}
+
+// CachePath returns where owner/repo@tag would be (or already is) cached.
+func (f *Fetcher) CachePath(owner, repo, tag string) string {
+	return filepath.Join(f.cacheDir, owner, repo, tag)
+}
+
+// Fetch downloads the release tarball for owner/repo@tag, verifies it against
+// the release's checksums.txt, extracts it into the cache and returns the
+// directory it was extracted to. If the tag is already cached, Fetch returns
+// ErrTagAlreadyCached without touching the network.
+func (f *Fetcher) Fetch(owner, repo, tag string) (string, error) {
+
+	dest := f.CachePath(owner, repo, tag)
+	if _, err := os.Stat(dest); err == nil {
+		return dest, ErrTagAlreadyCached
+	}
+
+	assetsURL := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", githubAPIBase, owner, repo, tag)
+	assets, err := f.listReleaseAssets(assetsURL)
+	if err != nil {
+		return "", err
+	}
+
+	tarballURL, checksumsURL, err := findAssets(assets)
+	if err != nil {
+		return "", err
+	}
+
+	tarballBytes, err := f.download(tarballURL)
+	if err != nil {
+		return "", err
+	}
+
+	checksumsBytes, err := f.download(checksumsURL)
+	if err != nil {
+		return "", err
+	}
+
+	if err := verifyChecksum(tarballBytes, checksumsBytes, filepath.Base(tarballURL)); err != nil {
+		return "", err
+	}
+
+	parent := filepath.Dir(dest)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", fmt.Errorf("unable to create cache directory '%s': %w", parent, err)
+	}
+
+	staging, err := ioutil.TempDir(parent, ".tmp-")
+	if err != nil {
+		return "", fmt.Errorf("unable to create staging directory for '%s': %w", dest, err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := extractTarGz(tarballBytes, staging); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(staging, dest); err != nil {
+		return "", fmt.Errorf("unable to move extracted ruleset bundle into '%s': %w", dest, err)
+	}
+
+	return dest, nil
+}
+
+type releaseAsset struct {
+	Name               string
+	BrowserDownloadURL string
+}
+
+func (f *Fetcher) listReleaseAssets(url string) ([]releaseAsset, error) {
+
+	resp, err := f.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if rateLimited(resp) {
+		return nil, rateLimitError(resp)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub returned %s for %s", resp.Status, url)
+	}
+
+	var body struct {
+		Assets []struct {
+			Name               string `json:"name"`
+			BrowserDownloadURL string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("unable to parse GitHub release response: %w", err)
+	}
+
+	assets := make([]releaseAsset, 0, len(body.Assets))
+	for _, a := range body.Assets {
+		assets = append(assets, releaseAsset{Name: a.Name, BrowserDownloadURL: a.BrowserDownloadURL})
+	}
+	return assets, nil
+}
+
+func findAssets(assets []releaseAsset) (tarballURL, checksumsURL string, err error) {
+	for _, a := range assets {
+		switch {
+		case strings.HasSuffix(a.Name, ".tar.gz"):
+			tarballURL = a.BrowserDownloadURL
+		case a.Name == "checksums.txt":
+			checksumsURL = a.BrowserDownloadURL
+		}
+	}
+	if tarballURL == "" {
+		return "", "", fmt.Errorf("release has no .tar.gz ruleset bundle attached")
+	}
+	if checksumsURL == "" {
+		return "", "", fmt.Errorf("release has no checksums.txt attached")
+	}
+	return tarballURL, checksumsURL, nil
+}
+
+func (f *Fetcher) download(url string) ([]byte, error) {
+
+	resp, err := f.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download '%s': %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if rateLimited(resp) {
+		return nil, rateLimitError(resp)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub returned %s for '%s'", resp.Status, url)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func rateLimited(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusForbidden && resp.Header.Get("x-ratelimit-remaining") == "0"
+}
+
+func rateLimitError(resp *http.Response) error {
+
+	resetAt := time.Now().Add(time.Hour)
+	if raw := resp.Header.Get("x-ratelimit-reset"); raw != "" {
+		if secs, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			resetAt = time.Unix(secs, 0)
+		}
+	}
+	return &ErrRateLimited{ResetAt: resetAt}
+}
+
+func verifyChecksum(tarballBytes, checksumsBytes []byte, name string) error {
+
+	var want string
+	for _, line := range strings.Split(string(checksumsBytes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == name {
+			want = fields[0]
+			break
+		}
+	}
+	if want == "" {
+		return fmt.Errorf("no checksum entry found for '%s'", name)
+	}
+
+	sum := sha256.Sum256(tarballBytes)
+	got := hex.EncodeToString(sum[:])
+	if got != want {
+		return fmt.Errorf("checksum mismatch for '%s': want %s, got %s", name, want, got)
+	}
+	return nil
+}
+
+func extractTarGz(data []byte, dest string) error {
+
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("unable to decompress ruleset bundle: %w", err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read ruleset bundle: %w", err)
+		}
+
+		target := filepath.Join(dest, filepath.Clean(hdr.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+			return fmt.Errorf("ruleset bundle contains an unsafe path: %s", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			out.Close()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package rulesets
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAssets(t *testing.T) {
+	assets := []releaseAsset{
+		{Name: "ruleset.tar.gz", BrowserDownloadURL: "https://example.com/ruleset.tar.gz"},
+		{Name: "checksums.txt", BrowserDownloadURL: "https://example.com/checksums.txt"},
+	}
+	tarballURL, checksumsURL, err := findAssets(assets)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/ruleset.tar.gz", tarballURL)
+	assert.Equal(t, "https://example.com/checksums.txt", checksumsURL)
+}
+
+func TestFindAssets_MissingTarball(t *testing.T) {
+	_, _, err := findAssets([]releaseAsset{{Name: "checksums.txt"}})
+	assert.Error(t, err)
+}
+
+func TestFindAssets_MissingChecksums(t *testing.T) {
+	_, _, err := findAssets([]releaseAsset{{Name: "ruleset.tar.gz"}})
+	assert.Error(t, err)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("ruleset contents")
+	sum := sha256.Sum256(data)
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  ruleset.tar.gz\n")
+
+	assert.NoError(t, verifyChecksum(data, checksums, "ruleset.tar.gz"))
+}
+
+func TestVerifyChecksum_Mismatch(t *testing.T) {
+	checksums := []byte(hex.EncodeToString(make([]byte, 32)) + "  ruleset.tar.gz\n")
+	assert.Error(t, verifyChecksum([]byte("ruleset contents"), checksums, "ruleset.tar.gz"))
+}
+
+func TestVerifyChecksum_NoEntry(t *testing.T) {
+	checksums := []byte("deadbeef  some-other-file.tar.gz\n")
+	assert.Error(t, verifyChecksum([]byte("ruleset contents"), checksums, "ruleset.tar.gz"))
+}
+
+func TestRateLimited(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusForbidden,
+		Header:     http.Header{"X-Ratelimit-Remaining": []string{"0"}},
+	}
+	assert.True(t, rateLimited(resp))
+}
+
+func TestRateLimited_NotForbidden(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusOK}
+	assert.False(t, rateLimited(resp))
+}
+
+func TestRateLimitError(t *testing.T) {
+	resetAt := time.Now().Add(2 * time.Hour).Unix()
+	resp := &http.Response{
+		Header: http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(resetAt, 10)}},
+	}
+	err := rateLimitError(resp)
+	var rateLimitErr *ErrRateLimited
+	assert.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, resetAt, rateLimitErr.ResetAt.Unix())
+}
+
+func TestExtractTarGz(t *testing.T) {
+	dest := t.TempDir()
+	assert.NoError(t, extractTarGz(buildTarGz(t, map[string]string{"ruleset.yaml": "rules: {}"}), dest))
+
+	contents, err := ioutil.ReadFile(filepath.Join(dest, "ruleset.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "rules: {}", string(contents))
+}
+
+func TestExtractTarGz_RejectsPathTraversal(t *testing.T) {
+	dest := t.TempDir()
+	err := extractTarGz(buildTarGz(t, map[string]string{"../../../etc/passwd": "pwned"}), dest)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsafe path")
+}
+
+func buildTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	for name, contents := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: int64(len(contents)),
+		}))
+		_, err := tw.Write([]byte(contents))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzw.Close())
+
+	return buf.Bytes()
+}
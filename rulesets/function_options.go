@@ -0,0 +1,79 @@
+package rulesets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// knownFunctionOptions lists the option keys each core rule function accepts.
+var knownFunctionOptions = map[string][]string{
+	"definedAll": {"fields"},
+	"definedAny": {"fields"},
+	"definedIf":  {"field", "equals"},
+}
+
+// requiredFunctionOptions lists the option keys each core rule function needs
+// to do anything; without them the function silently becomes a no-op.
+var requiredFunctionOptions = map[string][]string{
+	"definedAll": {"fields"},
+	"definedAny": {"fields"},
+	"definedIf":  {"field", "equals"},
+}
+
+// ValidateFunctionOptions checks that every key in options is one this
+// function understands, and that every option it requires is present.
+// Functions with no entry in knownFunctionOptions are left unchecked, since
+// not every core function's options are enumerable yet.
+func ValidateFunctionOptions(function string, options map[string]interface{}) error {
+
+	allowed, ok := knownFunctionOptions[function]
+	if !ok {
+		return nil
+	}
+
+	var unknown []string
+	for key := range options {
+		if !contains(allowed, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("function '%s' does not support option(s) '%s', only '%s'",
+			function, strings.Join(unknown, "', '"), strings.Join(allowed, "', '"))
+	}
+
+	var missing []string
+	for _, key := range requiredFunctionOptions[function] {
+		if _, ok := options[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("function '%s' requires option(s) '%s'",
+			function, strings.Join(missing, "', '"))
+	}
+
+	return nil
+}
+
+// ValidateRuleSet runs ValidateFunctionOptions against every rule in rs,
+// returning an error naming the first offending rule id.
+func ValidateRuleSet(rs *RuleSet) error {
+	for id, rule := range rs.Rules {
+		if err := ValidateFunctionOptions(rule.Then.Function, rule.Then.FunctionOptions); err != nil {
+			return fmt.Errorf("rule '%s': %w", id, err)
+		}
+	}
+	return nil
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFunctionOptions_Unknown(t *testing.T) {
+	err := ValidateFunctionOptions("definedAll", map[string]interface{}{"feilds": []interface{}{"a"}})
+	assert.Error(t, err)
+}
+
+func TestValidateFunctionOptions_Known(t *testing.T) {
+	err := ValidateFunctionOptions("definedAll", map[string]interface{}{"fields": []interface{}{"a"}})
+	assert.NoError(t, err)
+}
+
+func TestValidateFunctionOptions_UncheckedFunction(t *testing.T) {
+	err := ValidateFunctionOptions("truthy", map[string]interface{}{"whatever": true})
+	assert.NoError(t, err)
+}
+
+func TestValidateFunctionOptions_MissingRequired(t *testing.T) {
+	err := ValidateFunctionOptions("definedIf", map[string]interface{}{"field": "method"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "equals")
+}
+
+func TestValidateRuleSet_RejectsUnknownOption(t *testing.T) {
+	rs := &RuleSet{
+		Rules: map[string]*Rule{
+			"test-rule": {
+				Then: RuleAction{
+					Function:        "definedAll",
+					FunctionOptions: map[string]interface{}{"feilds": []interface{}{"title"}},
+				},
+			},
+		},
+	}
+	err := ValidateRuleSet(rs)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "test-rule")
+}
+
+func TestValidateRuleSet_AcceptsKnownOption(t *testing.T) {
+	rs := &RuleSet{
+		Rules: map[string]*Rule{
+			"test-rule": {
+				Then: RuleAction{
+					Function:        "definedAll",
+					FunctionOptions: map[string]interface{}{"fields": []interface{}{"title"}},
+				},
+			},
+		},
+	}
+	assert.NoError(t, ValidateRuleSet(rs))
+}
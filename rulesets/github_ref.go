@@ -0,0 +1,56 @@
+package rulesets
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// githubRefPrefix marks a --ruleset value as a cached community ruleset rather
+// than a local path, e.g. "github:owner/repo@v1.2.0".
+const githubRefPrefix = "github:"
+
+// IsGitHubRef reports whether ref looks like a "github:owner/repo@tag" value.
+func IsGitHubRef(ref string) bool {
+	return strings.HasPrefix(ref, githubRefPrefix)
+}
+
+// ParseGitHubRef splits a "github:owner/repo@tag" value into its parts.
+func ParseGitHubRef(ref string) (owner, repo, tag string, err error) {
+
+	trimmed := strings.TrimPrefix(ref, githubRefPrefix)
+
+	ownerRepo, tag, ok := strings.Cut(trimmed, "@")
+	if !ok || tag == "" {
+		return "", "", "", fmt.Errorf("'%s' must be in the form github:owner/repo@tag", ref)
+	}
+
+	owner, repo, ok = strings.Cut(ownerRepo, "/")
+	if !ok || owner == "" || repo == "" {
+		return "", "", "", fmt.Errorf("'%s' must be in the form github:owner/repo@tag", ref)
+	}
+
+	return owner, repo, tag, nil
+}
+
+// ResolveGitHubRef fetches (if necessary) and returns the path to the
+// ruleset.yaml at the root of a cached github:owner/repo@tag bundle.
+func ResolveGitHubRef(ref string) (string, error) {
+
+	owner, repo, tag, err := ParseGitHubRef(ref)
+	if err != nil {
+		return "", err
+	}
+
+	fetcher, err := NewFetcher()
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := fetcher.Fetch(owner, repo, tag)
+	if err != nil && err != ErrTagAlreadyCached {
+		return "", err
+	}
+
+	return filepath.Join(dir, "ruleset.yaml"), nil
+}
@@ -0,0 +1,26 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGitHubRef(t *testing.T) {
+
+	owner, repo, tag, err := ParseGitHubRef("github:daveshanley/vacuum-rules@v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "daveshanley", owner)
+	assert.Equal(t, "vacuum-rules", repo)
+	assert.Equal(t, "v1.0.0", tag)
+}
+
+func TestParseGitHubRef_Invalid(t *testing.T) {
+	_, _, _, err := ParseGitHubRef("github:daveshanley/vacuum-rules")
+	assert.Error(t, err)
+}
+
+func TestIsGitHubRef(t *testing.T) {
+	assert.True(t, IsGitHubRef("github:owner/repo@tag"))
+	assert.False(t, IsGitHubRef("./my-ruleset.yaml"))
+}
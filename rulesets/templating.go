@@ -0,0 +1,112 @@
+package rulesets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templatedRuleSet is the shape vacuum looks for at the top of a ruleset YAML
+// file before rendering it: an optional `environments:` map of named blocks of
+// arbitrary values, keyed by environment name.
+type templatedRuleSet struct {
+	Environments map[string]map[string]interface{} `yaml:"environments"`
+}
+
+// templateData is what `{{ .Values.xyz }}` resolves against inside a ruleset.
+// Values merges the selected environment block with whatever was loaded from
+// an external --values file, with the values file taking precedence so teams
+// can override a checked-in environment block locally without editing it.
+type templateData struct {
+	Values map[string]templateValue
+}
+
+// templateValue wraps a resolved value so text/template's default
+// stringification (which it uses for any type implementing fmt.Stringer)
+// renders it as valid YAML rather than Go's native format. A scalar renders
+// as-is, e.g. `{{ .Values.strictness }}` still yields a bare `error`; a list
+// or map (e.g. an allowed-tags list) renders as a YAML/JSON flow value like
+// `["a","b"]` instead of Go's `[a b]`, which isn't valid YAML and would be
+// read back as a single string.
+type templateValue struct {
+	raw interface{}
+}
+
+func (v templateValue) String() string {
+	switch v.raw.(type) {
+	case string, bool, int, int64, float64, nil:
+		return fmt.Sprintf("%v", v.raw)
+	default:
+		b, err := json.Marshal(v.raw)
+		if err != nil {
+			return fmt.Sprintf("%v", v.raw)
+		}
+		return string(b)
+	}
+}
+
+// RenderTemplatedRuleSet resolves `{{ .Values.* }}` references in a ruleset YAML
+// file before it's parsed into a RuleSet. environment selects a block under the
+// file's own `environments:` section (ignored if empty); valuesPath, if set, is
+// a YAML file merged on top of that block. This lets one ruleset behave
+// differently across dev, staging and prod without duplicating the file.
+func RenderTemplatedRuleSet(raw []byte, environment string, valuesPath string) ([]byte, error) {
+
+	var trs templatedRuleSet
+	if err := yaml.Unmarshal(raw, &trs); err != nil {
+		return nil, fmt.Errorf("unable to parse ruleset to resolve environments: %w", err)
+	}
+
+	values := make(map[string]interface{})
+
+	if environment != "" {
+		envValues, ok := trs.Environments[environment]
+		if !ok {
+			return nil, fmt.Errorf("ruleset has no 'environments.%s' block", environment)
+		}
+		for k, v := range envValues {
+			values[k] = v
+		}
+	}
+
+	if valuesPath != "" {
+		valuesBytes, err := ioutil.ReadFile(valuesPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read values file '%s': %w", valuesPath, err)
+		}
+		var fileValues map[string]interface{}
+		if err := yaml.Unmarshal(valuesBytes, &fileValues); err != nil {
+			return nil, fmt.Errorf("unable to parse values file '%s': %w", valuesPath, err)
+		}
+		for k, v := range fileValues {
+			values[k] = v
+		}
+	}
+
+	if len(values) == 0 {
+		// nothing to resolve: return the ruleset untouched so a plain YAML
+		// file with no templating in it behaves exactly as it always has.
+		return raw, nil
+	}
+
+	tmpl, err := template.New("ruleset").Option("missingkey=error").Parse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ruleset as a template: %w", err)
+	}
+
+	wrapped := make(map[string]templateValue, len(values))
+	for k, v := range values {
+		wrapped[k] = templateValue{raw: v}
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, templateData{Values: wrapped}); err != nil {
+		return nil, fmt.Errorf("unable to render ruleset template: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
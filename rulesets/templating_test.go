@@ -0,0 +1,45 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderTemplatedRuleSet_NoValues(t *testing.T) {
+	raw := []byte("rules:\n  test-rule:\n    severity: error\n")
+	rendered, err := RenderTemplatedRuleSet(raw, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, raw, rendered)
+}
+
+func TestRenderTemplatedRuleSet_Environment(t *testing.T) {
+	raw := []byte("environments:\n  production:\n    strictness: error\nrules:\n  test-rule:\n    severity: {{ .Values.strictness }}\n")
+	rendered, err := RenderTemplatedRuleSet(raw, "production", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "environments:\n  production:\n    strictness: error\nrules:\n  test-rule:\n    severity: error\n", string(rendered))
+}
+
+func TestRenderTemplatedRuleSet_UnknownEnvironment(t *testing.T) {
+	raw := []byte("environments:\n  production:\n    strictness: error\nrules: {}\n")
+	_, err := RenderTemplatedRuleSet(raw, "staging", "")
+	assert.Error(t, err)
+}
+
+func TestRenderTemplatedRuleSet_NonScalarValue(t *testing.T) {
+	raw := []byte("environments:\n  production:\n    allowedTags:\n      - billing\n      - users\n" +
+		"rules:\n  test-rule:\n    functionOptions:\n      tags: {{ .Values.allowedTags }}\n")
+	rendered, err := RenderTemplatedRuleSet(raw, "production", "")
+	assert.NoError(t, err)
+
+	var parsed struct {
+		Rules map[string]struct {
+			FunctionOptions struct {
+				Tags []string `yaml:"tags"`
+			} `yaml:"functionOptions"`
+		} `yaml:"rules"`
+	}
+	assert.NoError(t, yaml.Unmarshal(rendered, &parsed))
+	assert.Equal(t, []string{"billing", "users"}, parsed.Rules["test-rule"].FunctionOptions.Tags)
+}
@@ -0,0 +1,182 @@
+package watch
+
+import (
+	"sync"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// resultKey identifies a RuleFunctionResult across two runs: same rule, same
+// line. A changed message is a Changed entry, not a Removed+Added pair.
+type resultKey struct {
+	rule string
+	line int
+}
+
+func keyFor(r model.RuleFunctionResult) resultKey {
+	return resultKey{rule: r.Rule.Id, line: r.StartNode.Line}
+}
+
+// Delta is what a subscriber receives when the results for a watched spec
+// change: only what's different from the subscriber's last known state.
+type Delta struct {
+	Path    string
+	Added   []model.RuleFunctionResult
+	Removed []model.RuleFunctionResult
+	Changed []model.RuleFunctionResult
+}
+
+// Filter narrows which results within a Delta a given subscriber cares about.
+type Filter struct {
+	MinSeverity string
+	Categories  []string
+}
+
+func (f Filter) matches(r model.RuleFunctionResult) bool {
+	if f.MinSeverity != "" && !severityAtLeast(r.Rule.Severity, f.MinSeverity) {
+		return false
+	}
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, c := range f.Categories {
+		if r.Rule.RuleCategory != nil && r.Rule.RuleCategory.Id == c {
+			return true
+		}
+	}
+	return false
+}
+
+var severityRank = map[string]int{"hint": 0, "info": 1, "warn": 2, "error": 3}
+
+func severityAtLeast(severity, min string) bool {
+	return severityRank[severity] >= severityRank[min]
+}
+
+type subscriber struct {
+	id     uint64
+	path   string
+	filter Filter
+	ch     chan Delta
+}
+
+// Hub is a small in-process pub/sub shared by the `vacuum watch` terminal
+// reporter and any number of gRPC FollowResults streams.
+type Hub struct {
+	mu        sync.Mutex
+	nextID    uint64
+	lastState map[string][]model.RuleFunctionResult
+	subs      map[string][]*subscriber
+}
+
+// NewHub builds an empty Hub ready to have specs Published and subscribers added.
+func NewHub() *Hub {
+	return &Hub{
+		lastState: make(map[string][]model.RuleFunctionResult),
+		subs:      make(map[string][]*subscriber),
+	}
+}
+
+// Subscribe registers interest in a spec path and returns a channel of deltas
+// plus an unsubscribe func. If the spec already has known results, the
+// subscriber immediately receives them as an Added delta.
+func (h *Hub) Subscribe(path string, filter Filter) (<-chan Delta, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	sub := &subscriber{id: h.nextID, path: path, filter: filter, ch: make(chan Delta, 8)}
+	h.subs[path] = append(h.subs[path], sub)
+
+	if existing := h.lastState[path]; len(existing) > 0 {
+		sub.ch <- Delta{Path: path, Added: filterResults(existing, filter)}
+	}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		subs := h.subs[path]
+		for i, s := range subs {
+			if s.id == sub.id {
+				h.subs[path] = append(subs[:i], subs[i+1:]...)
+				close(s.ch)
+				break
+			}
+		}
+	}
+
+	return sub.ch, unsubscribe
+}
+
+// Publish diffs results against the last known state for path and fans the
+// resulting Delta out to every subscriber of that path, applying each
+// subscriber's own severity/category filter.
+func (h *Hub) Publish(path string, results []model.RuleFunctionResult) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delta := diff(h.lastState[path], results)
+	h.lastState[path] = results
+
+	if len(delta.Added) == 0 && len(delta.Removed) == 0 && len(delta.Changed) == 0 {
+		return
+	}
+
+	for _, sub := range h.subs[path] {
+		d := Delta{
+			Path:    path,
+			Added:   filterResults(delta.Added, sub.filter),
+			Removed: filterResults(delta.Removed, sub.filter),
+			Changed: filterResults(delta.Changed, sub.filter),
+		}
+		if len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 {
+			continue
+		}
+		select {
+		case sub.ch <- d:
+		default:
+			// slow subscriber: drop the delta rather than block the pipeline.
+		}
+	}
+}
+
+func diff(before, after []model.RuleFunctionResult) Delta {
+
+	beforeByKey := make(map[resultKey]model.RuleFunctionResult, len(before))
+	for _, r := range before {
+		beforeByKey[keyFor(r)] = r
+	}
+
+	afterByKey := make(map[resultKey]model.RuleFunctionResult, len(after))
+	var added, changed []model.RuleFunctionResult
+	for _, r := range after {
+		k := keyFor(r)
+		afterByKey[k] = r
+		before, existed := beforeByKey[k]
+		switch {
+		case !existed:
+			added = append(added, r)
+		case before.Message != r.Message:
+			changed = append(changed, r)
+		}
+	}
+
+	var removed []model.RuleFunctionResult
+	for k, r := range beforeByKey {
+		if _, ok := afterByKey[k]; !ok {
+			removed = append(removed, r)
+		}
+	}
+
+	return Delta{Added: added, Removed: removed, Changed: changed}
+}
+
+func filterResults(results []model.RuleFunctionResult, filter Filter) []model.RuleFunctionResult {
+	var out []model.RuleFunctionResult
+	for _, r := range results {
+		if filter.matches(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package watch
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func result(ruleID string, line int, message string) model.RuleFunctionResult {
+	return model.RuleFunctionResult{
+		Message:   message,
+		StartNode: &yaml.Node{Line: line},
+		Rule:      &model.Rule{Id: ruleID},
+	}
+}
+
+func TestDiff_ChangedMessageIsChangedNotAddedAndRemoved(t *testing.T) {
+
+	before := []model.RuleFunctionResult{result("no-unused-tags", 12, "tag 'foo' is unused")}
+	after := []model.RuleFunctionResult{result("no-unused-tags", 12, "tag 'bar' is unused")}
+
+	delta := diff(before, after)
+
+	assert.Empty(t, delta.Added)
+	assert.Empty(t, delta.Removed)
+	assert.Len(t, delta.Changed, 1)
+	assert.Equal(t, "tag 'bar' is unused", delta.Changed[0].Message)
+}
+
+func TestDiff_SameResultIsNeitherAddedRemovedNorChanged(t *testing.T) {
+
+	results := []model.RuleFunctionResult{result("no-unused-tags", 12, "tag 'foo' is unused")}
+
+	delta := diff(results, results)
+
+	assert.Empty(t, delta.Added)
+	assert.Empty(t, delta.Removed)
+	assert.Empty(t, delta.Changed)
+}
+
+func TestDiff_AddedAndRemoved(t *testing.T) {
+
+	before := []model.RuleFunctionResult{result("no-unused-tags", 12, "tag 'foo' is unused")}
+	after := []model.RuleFunctionResult{result("require-title", 3, "'title' must be defined")}
+
+	delta := diff(before, after)
+
+	assert.Len(t, delta.Added, 1)
+	assert.Len(t, delta.Removed, 1)
+	assert.Empty(t, delta.Changed)
+}
@@ -0,0 +1,134 @@
+package watch
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pterm/pterm"
+)
+
+// debounce absorbs the burst of fsnotify events a single save often produces
+// (most editors write, chmod, then rename) into one re-lint.
+const debounce = 150 * time.Millisecond
+
+// Watcher re-runs a RuleSet against one or more spec files whenever they
+// change on disk, publishing the results to a Hub so any number of
+// subscribers (terminal reporter, gRPC streams) see the same lint pipeline.
+//
+// Many editors save by writing a new file and renaming it over the original,
+// which replaces the inode fsnotify is watching and would otherwise leave a
+// watched path silently dead. Watcher works around this by watching each
+// path's parent directory and filtering events by filename instead of
+// watching the file itself.
+type Watcher struct {
+	ruleSet *rulesets.RuleSet
+	hub     *Hub
+	fsw     *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewWatcher builds a Watcher that lints against ruleSet and publishes to hub.
+func NewWatcher(ruleSet *rulesets.RuleSet, hub *Hub) (*Watcher, error) {
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Watcher{ruleSet: ruleSet, hub: hub, fsw: fsw, done: make(chan struct{})}, nil
+}
+
+// Watch adds paths to the underlying fsnotify watcher, lints each of them once
+// immediately, and starts the background loop that re-lints on change. Each
+// path's parent directory is watched rather than the path itself, so an
+// atomic-save replacing the file's inode doesn't drop the watch.
+func (w *Watcher) Watch(paths ...string) error {
+
+	dirs := make(map[string]bool)
+
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		if !dirs[dir] {
+			if err := w.fsw.Add(dir); err != nil {
+				return err
+			}
+			dirs[dir] = true
+		}
+		w.lint(path)
+	}
+
+	go w.loop(paths)
+
+	return nil
+}
+
+// Close stops the watch loop and releases the underlying fsnotify watcher.
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.fsw.Close()
+}
+
+func (w *Watcher) loop(paths []string) {
+
+	watched := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		watched[filepath.Clean(path)] = true
+	}
+
+	pending := make(map[string]*time.Timer)
+
+	for {
+		select {
+		case <-w.done:
+			return
+
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			// Watching the parent directory also catches events for unrelated
+			// files in it, plus the Remove+Create pair an atomic save produces
+			// for the watched path itself: both are handled the same way here,
+			// by re-linting whichever of our paths the event names.
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			path := filepath.Clean(event.Name)
+			if !watched[path] {
+				continue
+			}
+
+			if t, exists := pending[path]; exists {
+				t.Stop()
+			}
+			pending[path] = time.AfterFunc(debounce, func() { w.lint(path) })
+
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			pterm.Error.Printf("watch error: %s\n", err.Error())
+		}
+	}
+}
+
+func (w *Watcher) lint(path string) {
+
+	specBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		pterm.Error.Printf("unable to read '%s': %s\n", path, err.Error())
+		return
+	}
+
+	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: w.ruleSet,
+		Spec:    specBytes,
+	})
+
+	w.hub.Publish(path, result.Results)
+}